@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"go-jijin-monitor/notifier"
+)
+
+// startAdminServer 启动后台管理HTTP服务，addr 为空则不启动
+func startAdminServer(addr string) {
+	if addr == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/state", handleState)
+	mux.HandleFunc("/reset", handleReset)
+	mux.HandleFunc("/healthz", handleHealthz)
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("后台管理服务退出: %v", err)
+		}
+	}()
+}
+
+// handleState GET /state 返回当前 LogMap 的快照，便于运维查看告警阶梯的进度
+func handleState(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	logMapMu.Lock()
+	snapshot := make(map[string]*LogData, len(LogMap))
+	for k, v := range LogMap {
+		copied := *v
+		snapshot[k] = &copied
+	}
+	logMapMu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(snapshot)
+}
+
+// handleReset POST /reset?code=xxx 清除某只基金当天的告警状态，无需重启进程即可重新布防
+func handleReset(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	code := req.URL.Query().Get("code")
+	if code == "" {
+		http.Error(w, "missing code", http.StatusBadRequest)
+		return
+	}
+
+	key := code + time.Now().Format("2006-01-02")
+
+	logMapMu.Lock()
+	delete(LogMap, key)
+	logMapMu.Unlock()
+	saveState()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleHealthz GET /healthz 用于容器探活，同时返回各通知渠道最近一次发送的结果，
+// 便于运维在不翻日志的情况下发现某个渠道持续发送失败
+func handleHealthz(w http.ResponseWriter, req *http.Request) {
+	var channels []notifier.ChannelStatus
+	if dispatcher != nil {
+		channels = dispatcher.Status()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":   "ok",
+		"channels": channels,
+	})
+}
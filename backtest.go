@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ======================= 回放 / 录制 ==========================
+
+var mode = flag.String("mode", "live", "运行模式：live|backtest")
+var backtestDir = flag.String("backtestDir", "./snapshots", "backtest模式下读取快照的目录，文件名格式 YYYYMMDD-HHMMSS.json")
+var recordDir = flag.String("record", "", "live模式下把每次成功拉取的快照写入该目录，不填则不记录")
+var outFile = flag.String("out", "", "backtest模式下告警输出文件，不填则输出到stdout")
+
+// alertSink 是告警文本的最终出口，live模式下默认等价于 PrintLog，backtest模式下被
+// 替换为写 stdout 或 -out 文件，不会触达真实的企业微信
+var alertSink = func(msg string) {
+	PrintLog(msg)
+}
+
+// recordSnapshot 把一次成功拉取到的快照写入 dir/YYYYMMDD-HHMMSS.json，供日后回放使用
+func recordSnapshot(dir string, dataMap map[string]JSONData) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Printf("创建record目录失败: %v", err)
+		return
+	}
+
+	list := make([]JSONData, 0, len(dataMap))
+	for _, item := range dataMap {
+		list = append(list, item)
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		log.Printf("序列化快照失败: %v", err)
+		return
+	}
+
+	path := filepath.Join(dir, time.Now().Format("20060102-150405")+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		log.Printf("写入快照失败: %v", err)
+	}
+}
+
+// writeAlertOutput 把告警文本输出到 stdout 或 -out 文件，backtest模式专用
+func writeAlertOutput(msg string) {
+	if *outFile == "" {
+		fmt.Println(msg)
+		return
+	}
+
+	f, err := os.OpenFile(*outFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Printf("写入 -out 文件失败: %v", err)
+		return
+	}
+	defer f.Close()
+
+	fmt.Fprintln(f, msg)
+}
+
+// backtestStat 汇总某只基金在整段回放期间触发的告警
+type backtestStat struct {
+	// Count/First/Last 只统计涨跌阶梯（UpIndex/DownIndex）前进的帧，高开低开通知不计入
+	Count          int
+	First, Last    string
+	MaxUp, MaxDown float64
+	// LadderHist 按快照累计阶梯下标：UpIndex 记在正下标，DownIndex 取负记在负下标，
+	// 每个快照两者各记一次（两者都为0时下标0记两次）
+	LadderHist map[int]int
+}
+
+// backtestDate 把快照文件名（"20060102-150405"）还原成 LogMap 使用的
+// "2006-01-02" 日期。这样回放跨越多天的快照语料时，每一天各自拥有独立的
+// LogMap key，不会像直接用 time.Now() 那样把所有日子的"今日已通知"/斐波那契
+// 阶梯状态坍缩成同一天，导致后面几天的告警被前面几天的状态误判为重复而吞掉
+func backtestDate(ts string) string {
+	t, err := time.Parse("20060102-150405", ts)
+	if err != nil {
+		return ts
+	}
+	return t.Format("2006-01-02")
+}
+
+// runBacktest 读取 -backtestDir 下按时间排序的快照文件，逐个喂给现有的
+// IsInitPrice/IsUpDownPrice/斐波那契逻辑，并在结束时打印统计摘要
+func runBacktest() {
+	alertSink = writeAlertOutput
+
+	files, err := filepath.Glob(filepath.Join(*backtestDir, "*.json"))
+	if err != nil {
+		log.Fatalf("读取backtest目录失败: %v", err)
+	}
+	sort.Strings(files)
+
+	codeArr := parseCodes(*codes)
+	stats := make(map[string]*backtestStat, len(codeArr))
+	lastUpIndex := make(map[string]int)
+	lastDownIndex := make(map[string]int)
+
+	for _, path := range files {
+		ts := strings.TrimSuffix(filepath.Base(path), ".json")
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("读取快照 %s 失败: %v", path, err)
+			continue
+		}
+		var list []JSONData
+		if err := json.Unmarshal(data, &list); err != nil {
+			log.Printf("解析快照 %s 失败: %v", path, err)
+			continue
+		}
+		dataMap := make(map[string]JSONData, len(list))
+		for _, item := range list {
+			dataMap[item.Code] = item
+		}
+
+		date := backtestDate(ts)
+
+		logStr := ""
+		for _, codeItem := range codeArr {
+			item, ok := dataMap[codeItem.Code]
+			if !ok {
+				logStr += fmt.Sprintf("code参数错误！没有找到该【%s】对应的基金\n\n", codeItem.Code)
+				continue
+			}
+
+			IsInitPrice(item, &logStr, date)
+			IsUpDownPrice(codeItem, item, &logStr, date)
+
+			recordBacktestStat(stats, lastUpIndex, lastDownIndex, codeItem, item, ts, date)
+		}
+
+		if logStr != "" {
+			alertSink(fmt.Sprintf("[%s]\n%s", ts, strings.TrimSuffix(logStr, "\n\n")))
+		}
+	}
+
+	printBacktestSummary(files, stats)
+}
+
+// recordBacktestStat 更新某只基金在 ts 这一帧的统计量，date 是 ts 对应的交易日，
+// 用来读取与该快照同一天的 LogData，而不是回放进程启动时的真实日期
+func recordBacktestStat(stats map[string]*backtestStat, lastUpIndex, lastDownIndex map[string]int, codeItem CodeRule, item JSONData, ts, date string) {
+	st, ok := stats[codeItem.Code]
+	if !ok {
+		st = &backtestStat{LadderHist: make(map[int]int)}
+		stats[codeItem.Code] = st
+	}
+
+	ratio, _ := calculatePercentageChange(item.Trade, item.Open).Float64()
+	if ratio > st.MaxUp {
+		st.MaxUp = ratio
+	}
+	if ratio < st.MaxDown {
+		st.MaxDown = ratio
+	}
+
+	logData := GetLogData(codeItem.Code, date)
+	st.LadderHist[logData.UpIndex]++
+	st.LadderHist[-logData.DownIndex]++
+
+	if logData.UpIndex != lastUpIndex[codeItem.Code] || logData.DownIndex != lastDownIndex[codeItem.Code] {
+		st.Count++
+		if st.First == "" {
+			st.First = ts
+		}
+		st.Last = ts
+		lastUpIndex[codeItem.Code] = logData.UpIndex
+		lastDownIndex[codeItem.Code] = logData.DownIndex
+	}
+}
+
+// printBacktestSummary 打印每个基金的告警统计，方便用户离线调整 -codes 里的涨跌阈值
+func printBacktestSummary(files []string, stats map[string]*backtestStat) {
+	fmt.Printf("\n========== backtest 摘要（共回放 %d 个快照）==========\n", len(files))
+
+	codes := make([]string, 0, len(stats))
+	for code := range stats {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		st := stats[code]
+		fmt.Printf("【%s】触发 %d 次，首次 %s，最后一次 %s，最大涨幅 %.2f%%，最大跌幅 %.2f%%\n",
+			code, st.Count, st.First, st.Last, st.MaxUp, st.MaxDown)
+
+		ladders := make([]int, 0, len(st.LadderHist))
+		for idx := range st.LadderHist {
+			ladders = append(ladders, idx)
+		}
+		sort.Ints(ladders)
+		for _, idx := range ladders {
+			fmt.Printf("  阶梯[%d]: %d 次\n", idx, st.LadderHist[idx])
+		}
+	}
+}
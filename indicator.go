@@ -0,0 +1,336 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ======================= K线与日内指标 ==========================
+
+// KlineBar 单根日K线
+type KlineBar struct {
+	Date   string
+	Open   *big.Float
+	High   *big.Float
+	Low    *big.Float
+	Close  *big.Float
+	Volume *big.Float
+}
+
+// KLineProvider 抽象K线数据源，默认使用新浪接口，测试/替换时可注入假实现
+type KLineProvider func(code string, n int) ([]KlineBar, error)
+
+var klineProvider KLineProvider = fetchKLine
+
+// stringToBigFloat 将字符串解析为 *big.Float
+func stringToBigFloat(s string) *big.Float {
+	f := new(big.Float)
+	f.SetString(s)
+	return f
+}
+
+// guessMarketPrefix 根据纯数字代码粗略猜测交易所前缀（sh/sz），用于拼接新浪的行情代码
+func guessMarketPrefix(code string) string {
+	if strings.HasPrefix(code, "5") || strings.HasPrefix(code, "6") || strings.HasPrefix(code, "9") {
+		return "sh"
+	}
+	return "sz"
+}
+
+// fetchKLine 从新浪获取最近 n 根日K线（由旧到新排列）
+func fetchKLine(code string, n int) ([]KlineBar, error) {
+	symbol := guessMarketPrefix(code) + code
+	url := fmt.Sprintf(
+		"http://money.finance.sina.com.cn/quotes_service/api/json_v2.php/CN_MarketData.getKLineData?symbol=%s&scale=240&ma=no&datalen=%d",
+		symbol, n,
+	)
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []struct {
+		Day    string `json:"day"`
+		Open   string `json:"open"`
+		High   string `json:"high"`
+		Low    string `json:"low"`
+		Close  string `json:"close"`
+		Volume string `json:"volume"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	bars := make([]KlineBar, 0, len(raw))
+	for _, item := range raw {
+		bars = append(bars, KlineBar{
+			Date:   item.Day,
+			Open:   stringToBigFloat(item.Open),
+			High:   stringToBigFloat(item.High),
+			Low:    stringToBigFloat(item.Low),
+			Close:  stringToBigFloat(item.Close),
+			Volume: stringToBigFloat(item.Volume),
+		})
+	}
+	return bars, nil
+}
+
+// Misc 某只基金一天内复用的统计量，每个交易日只需要计算一次
+type Misc struct {
+	Date         string     `json:"date"`
+	Code         string     `json:"code"`
+	MA3          *big.Float `json:"ma3"`
+	MA5          *big.Float `json:"ma5"`
+	MA10         *big.Float `json:"ma10"`
+	MA20         *big.Float `json:"ma20"`
+	MV5          *big.Float `json:"mv5"`          // 前5日分钟均量
+	VolumeRatio  *big.Float `json:"volumeRatio"`  // 当前量比，每次刷新时更新
+	TurnoverRate *big.Float `json:"turnoverRate"` // 换手率，无法获取股本数据时为 nil
+	PrevHigh     *big.Float `json:"prevHigh"`
+	PrevLow      *big.Float `json:"prevLow"`
+}
+
+// 一个交易日内连续竞价的总分钟数（09:30-11:30 + 13:00-15:00）
+const continuousMinutesPerDay = 240
+
+var (
+	miscCachePath = flag.String("miscCache", "./misc_cache.json", "MA/量比等日内指标缓存文件路径")
+	miscMu        sync.Mutex
+	miscMap       = make(map[string]*Misc)
+)
+
+// loadMiscCache 启动时从磁盘加载缓存，文件不存在时忽略
+func loadMiscCache() {
+	miscMu.Lock()
+	defer miscMu.Unlock()
+
+	data, err := os.ReadFile(*miscCachePath)
+	if err != nil {
+		return
+	}
+	var loaded map[string]*Misc
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		log.Printf("misc缓存解析失败，将忽略: %v", err)
+		return
+	}
+	miscMap = loaded
+}
+
+// saveMiscCache 将当前缓存写回磁盘
+func saveMiscCache() {
+	miscMu.Lock()
+	data, err := json.Marshal(miscMap)
+	miscMu.Unlock()
+	if err != nil {
+		log.Printf("misc缓存序列化失败: %v", err)
+		return
+	}
+	if err := os.WriteFile(*miscCachePath, data, 0644); err != nil {
+		log.Printf("misc缓存写盘失败: %v", err)
+	}
+}
+
+// averageClose 计算最近 n 根K线的收盘均价
+func averageClose(bars []KlineBar, n int) *big.Float {
+	if len(bars) < n {
+		return nil
+	}
+	sum := new(big.Float)
+	for _, b := range bars[len(bars)-n:] {
+		sum.Add(sum, b.Close)
+	}
+	return new(big.Float).Quo(sum, big.NewFloat(float64(n)))
+}
+
+// GetMisc 返回 code 当天的 Misc 缓存，过期（非今天）则重新拉K线刷新
+func GetMisc(code string) (*Misc, error) {
+	today := time.Now().Format("2006-01-02")
+
+	miscMu.Lock()
+	cached, ok := miscMap[code]
+	miscMu.Unlock()
+	if ok && cached.Date == today {
+		return cached, nil
+	}
+
+	bars, err := klineProvider(code, 30)
+	if err != nil {
+		return nil, err
+	}
+	if len(bars) < 2 {
+		return nil, fmt.Errorf("indicator: %s 可用K线数量不足", code)
+	}
+
+	prevBars := bars[:len(bars)-1]
+	mv5Sum := new(big.Float)
+	mv5Count := 0
+	mv5Start := len(prevBars) - 5
+	if mv5Start < 0 {
+		mv5Start = 0
+	}
+	for _, b := range prevBars[mv5Start:] {
+		mv5Sum.Add(mv5Sum, b.Volume)
+		mv5Count++
+	}
+	var mv5 *big.Float
+	if mv5Count > 0 {
+		mv5 = new(big.Float).Quo(mv5Sum, big.NewFloat(float64(mv5Count*continuousMinutesPerDay)))
+	}
+
+	prev := prevBars[len(prevBars)-1]
+	misc := &Misc{
+		Date:     today,
+		Code:     code,
+		MA3:      averageClose(bars, 3),
+		MA5:      averageClose(bars, 5),
+		MA10:     averageClose(bars, 10),
+		MA20:     averageClose(bars, 20),
+		MV5:      mv5,
+		PrevHigh: prev.High,
+		PrevLow:  prev.Low,
+	}
+
+	miscMu.Lock()
+	miscMap[code] = misc
+	miscMu.Unlock()
+	saveMiscCache()
+
+	return misc, nil
+}
+
+// elapsedMinutesSinceOpen 粗略估算从今天连续竞价开始到现在经过的分钟数，
+// 用于把当前累计成交量折算成可比的量比
+func elapsedMinutesSinceOpen(now time.Time) int {
+	morningOpen := time.Date(now.Year(), now.Month(), now.Day(), 9, 30, 0, 0, now.Location())
+	noonClose := time.Date(now.Year(), now.Month(), now.Day(), 11, 30, 0, 0, now.Location())
+	afternoonOpen := time.Date(now.Year(), now.Month(), now.Day(), 13, 0, 0, 0, now.Location())
+
+	switch {
+	case now.Before(morningOpen):
+		return 0
+	case now.Before(noonClose):
+		return int(now.Sub(morningOpen).Minutes())
+	case now.Before(afternoonOpen):
+		return 120
+	default:
+		return 120 + int(now.Sub(afternoonOpen).Minutes())
+	}
+}
+
+// CheckIndicatorAlerts 检查均线穿越、量比异动、前日新高新低三类告警，date 含义同 IsInitPrice
+func CheckIndicatorAlerts(codeItem CodeRule, dataItem JSONData, logStr *string, date string) {
+	if !codeItem.MAAlert && codeItem.VolumeRatio == nil && !codeItem.BreakAlert {
+		return
+	}
+
+	misc, err := GetMisc(dataItem.Code)
+	if err != nil {
+		*logStr += fmt.Sprintf("获取【%s】日内指标失败：%v\n\n", dataItem.Name, err)
+		return
+	}
+
+	// 下面这段除了在 miscMu 保护下回写 misc.VolumeRatio，只读写 LogData 的字段，
+	// 不再触发网络请求，全程持有 logMapMu，
+	// 避免和后台管理HTTP服务、防抖落盘对同一个 *LogData 产生数据竞争
+	var buf string
+
+	logMapMu.Lock()
+	logData, _ := getOrCreateLogDataLocked(dataItem.Code, date)
+
+	if codeItem.MAAlert {
+		checkMACross(dataItem, misc.MA5, &logData.MA5Side, "MA5", &buf)
+		checkMACross(dataItem, misc.MA10, &logData.MA10Side, "MA10", &buf)
+		checkMACross(dataItem, misc.MA20, &logData.MA20Side, "MA20", &buf)
+	}
+
+	if codeItem.VolumeRatio != nil && misc.MV5 != nil {
+		minutes := elapsedMinutesSinceOpen(time.Now())
+		denominator := new(big.Float).Mul(misc.MV5, big.NewFloat(float64(minutes)))
+		// denominator为0时（比如MV5本身是0）如果成交量也恰好是0，Quo(0,0)会panic；
+		// 这种时候量比本来就无意义，直接跳过本次计算
+		if minutes > 0 && denominator.Sign() != 0 {
+			volume := new(big.Float).SetInt64(int64(dataItem.Volume))
+			ratio := new(big.Float).Quo(volume, denominator)
+			miscMu.Lock()
+			misc.VolumeRatio = ratio
+			miscMu.Unlock()
+
+			i := logData.VolIndex
+			for {
+				index := IsFibonacciSequence(ratio, codeItem.VolumeRatio, fibonacciSequence, logData.VolIndex)
+				if index != logData.VolIndex {
+					logData.VolIndex = index
+				} else {
+					break
+				}
+			}
+			if logData.VolIndex != i {
+				buf += fmt.Sprintf("【%s】📊量比 %s\n\n", dataItem.Name, r(ratio.Text('f', 2)))
+			}
+		}
+	}
+
+	if codeItem.BreakAlert {
+		if misc.PrevHigh != nil && !logData.BreakHigh && dataItem.Trade.Cmp(misc.PrevHigh) > 0 {
+			logData.BreakHigh = true
+			buf += fmt.Sprintf("【%s】⬆️突破前日高点 %s\n\n", dataItem.Name, r(misc.PrevHigh.Text('f', 3)))
+		}
+		if misc.PrevLow != nil && !logData.BreakLow && dataItem.Trade.Cmp(misc.PrevLow) < 0 {
+			logData.BreakLow = true
+			buf += fmt.Sprintf("【%s】⬇️跌破前日低点 %s\n\n", dataItem.Name, r(misc.PrevLow.Text('f', 3)))
+		}
+	}
+	logMapMu.Unlock()
+
+	if buf != "" {
+		saveState()
+		*logStr += buf
+	}
+}
+
+// checkMACross 判断当前价相对某条均线的方位是否发生了翻转（真正的穿越），
+// 而不是"当天第一次看到价格在均线的哪一侧"——side为0时说明今天还没有过有效观察，
+// 只记录方位、不提醒，避免开盘价一直在均线同一侧也被误判成一次穿越
+func checkMACross(dataItem JSONData, ma *big.Float, side *int8, label string, logStr *string) {
+	if ma == nil {
+		return
+	}
+
+	var current int8
+	switch dataItem.Trade.Cmp(ma) {
+	case 1:
+		current = 1
+	case -1:
+		current = -1
+	default:
+		return
+	}
+
+	prev := *side
+	*side = current
+	if prev == 0 || prev == current {
+		return
+	}
+
+	if current == 1 {
+		*logStr += fmt.Sprintf("【%s】🔼价格上穿%s %s\n\n", dataItem.Name, label, r(ma.Text('f', 3)))
+	} else {
+		*logStr += fmt.Sprintf("【%s】🔽价格下穿%s %s\n\n", dataItem.Name, label, r(ma.Text('f', 3)))
+	}
+}
@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// defaultBarkServer 是官方Bark App默认使用的推送服务器
+const defaultBarkServer = "https://api.day.app"
+
+// BarkChannel 通过 Bark 把通知推送到 iOS 设备
+type BarkChannel struct {
+	Server string // 为空时使用 defaultBarkServer
+	Key    string
+}
+
+type barkResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Send 实现 Channel
+func (c *BarkChannel) Send(ctx context.Context, subject, body string) error {
+	server := c.Server
+	if server == "" {
+		server = defaultBarkServer
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s/%s", server, c.Key, url.PathEscape(subject), url.PathEscape(body))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result barkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if result.Code != 200 {
+		return fmt.Errorf("bark: code=%d message=%s", result.Code, result.Message)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package notifier
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// RateLimiter 是一个极简的单令牌限流器：两次 Wait 之间至少间隔 interval
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time
+}
+
+// NewRateLimiter 构造一个最小发送间隔为 interval 的限流器
+func NewRateLimiter(interval time.Duration) *RateLimiter {
+	return &RateLimiter{interval: interval}
+}
+
+// Wait 阻塞直到距离上一次调用已经过去至少 interval
+func (r *RateLimiter) Wait() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.last.IsZero() {
+		if elapsed := time.Since(r.last); elapsed < r.interval {
+			time.Sleep(r.interval - elapsed)
+		}
+	}
+	r.last = time.Now()
+}
+
+// ChannelStatus 记录某个渠道最近一次发送的结果，供 /healthz 展示
+type ChannelStatus struct {
+	Name    string
+	LastOK  time.Time
+	LastErr string
+}
+
+type channelEntry struct {
+	name    string
+	channel Channel
+	limiter *RateLimiter
+}
+
+// Dispatcher 把同一条告警并行投递给所有配置好的渠道，每个渠道独立限流、独立重试
+type Dispatcher struct {
+	entries []*channelEntry
+
+	mu     sync.Mutex
+	status map[string]*ChannelStatus
+}
+
+// NewDispatcher 按渠道名构造 Dispatcher，minInterval 是每个渠道各自的最小发送间隔
+func NewDispatcher(channels map[string]Channel, minInterval time.Duration) *Dispatcher {
+	d := &Dispatcher{status: make(map[string]*ChannelStatus)}
+	for name, ch := range channels {
+		d.entries = append(d.entries, &channelEntry{name: name, channel: ch, limiter: NewRateLimiter(minInterval)})
+	}
+	return d
+}
+
+// Dispatch 并行投递到所有渠道，单个渠道失败不影响其它渠道。它会同步等待所有渠道
+// （包括各自的重试）结束后才返回；发送结果不返回给调用方，只写日志并记录到 Status 中
+func (d *Dispatcher) Dispatch(ctx context.Context, subject, body string) {
+	var wg sync.WaitGroup
+	for _, e := range d.entries {
+		wg.Add(1)
+		go func(e *channelEntry) {
+			defer wg.Done()
+
+			e.limiter.Wait()
+			err := sendWithRetry(ctx, e.channel, subject, body, 3)
+			d.recordStatus(e.name, err)
+			if err != nil {
+				log.Printf("notifier: 渠道 %s 发送失败: %v", e.name, err)
+			}
+		}(e)
+	}
+	wg.Wait()
+}
+
+// sendWithRetry 最多共尝试 maxAttempts 次（含首次发送），两次尝试之间按指数退避等待
+func sendWithRetry(ctx context.Context, ch Channel, subject, body string, maxAttempts int) error {
+	var err error
+	backoff := 500 * time.Millisecond
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err = ch.Send(ctx, subject, body); err == nil {
+			return nil
+		}
+		if attempt < maxAttempts-1 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return err
+}
+
+func (d *Dispatcher) recordStatus(name string, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	st, ok := d.status[name]
+	if !ok {
+		st = &ChannelStatus{Name: name}
+		d.status[name] = st
+	}
+	if err != nil {
+		st.LastErr = err.Error()
+	} else {
+		st.LastErr = ""
+		st.LastOK = time.Now()
+	}
+}
+
+// Status 返回所有渠道最近一次发送的结果快照
+func (d *Dispatcher) Status() []ChannelStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	out := make([]ChannelStatus, 0, len(d.status))
+	for _, st := range d.status {
+		out = append(out, *st)
+	}
+	return out
+}
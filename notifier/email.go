@@ -0,0 +1,44 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// EmailChannel 通过SMTP发送邮件，正文同时提供纯文本与带涨跌颜色的HTML两种格式
+type EmailChannel struct {
+	Host     string // host:port，如 smtp.example.com:587
+	Username string
+	Password string
+	To       string
+}
+
+// Send 实现 Channel
+func (c *EmailChannel) Send(ctx context.Context, subject, body string) error {
+	host := c.Host
+	if idx := strings.LastIndex(host, ":"); idx > 0 {
+		host = host[:idx]
+	}
+	auth := smtp.PlainAuth("", c.Username, c.Password, host)
+
+	color := "black"
+	switch {
+	case strings.Contains(body, "🔴"):
+		color = "red"
+	case strings.Contains(body, "🟢"):
+		color = "green"
+	}
+
+	msg := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\n"+
+			"Content-Type: multipart/alternative; boundary=boundary42\r\n\r\n"+
+			"--boundary42\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n\r\n"+
+			"--boundary42\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n<p style=\"color:%s\">%s</p>\r\n\r\n"+
+			"--boundary42--",
+		c.Username, c.To, subject, body, color, body,
+	)
+
+	return smtp.SendMail(c.Host, auth, c.Username, []string{c.To}, []byte(msg))
+}
@@ -0,0 +1,11 @@
+// Package notifier 把"把一条告警发出去"这件事抽象成 Channel，
+// 上层通过 Dispatcher 把同一条告警并行投递给所有配置好的渠道
+package notifier
+
+import "context"
+
+// Channel 是单个通知渠道的抽象
+type Channel interface {
+	// Send 发送一条通知，subject 可以为空（部分渠道不区分标题与正文）
+	Send(ctx context.Context, subject, body string) error
+}
@@ -0,0 +1,32 @@
+package notifier
+
+import "testing"
+
+// TestParseChannelsTelegramRealTokenShape 确保形如 "123456:AAHdq..." 的真实
+// Telegram Bot Token（包含冒号）能够被 tg:///TOKEN/CHATID 配置串正确解析，
+// 而不会被 net/url 误判为host:port
+func TestParseChannelsTelegramRealTokenShape(t *testing.T) {
+	const token = "123456789:AAHdqTcvCH1vGWJxfSeofSAs0K5PALDsaw"
+	const chatID = "-100987654321"
+
+	channels, err := ParseChannels("tg:///" + token + "/" + chatID)
+	if err != nil {
+		t.Fatalf("ParseChannels 返回错误: %v", err)
+	}
+
+	ch, ok := channels["tg-1"]
+	if !ok {
+		t.Fatalf("未找到 tg-1 渠道，实际渠道: %v", channels)
+	}
+
+	tg, ok := ch.(*TelegramChannel)
+	if !ok {
+		t.Fatalf("渠道类型错误: %T", ch)
+	}
+	if tg.Token != token {
+		t.Errorf("Token = %q, want %q", tg.Token, token)
+	}
+	if tg.ChatID != chatID {
+		t.Errorf("ChatID = %q, want %q", tg.ChatID, chatID)
+	}
+}
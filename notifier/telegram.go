@@ -0,0 +1,72 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TelegramChannel 通过 Telegram Bot API 的 sendMessage 发送 MarkdownV2 格式的消息
+type TelegramChannel struct {
+	Token  string
+	ChatID string
+}
+
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// markdownV2Reserved 是 MarkdownV2 要求转义的保留字符
+const markdownV2Reserved = "_*[]()~`>#+-=|{}.!"
+
+// escapeMarkdownV2 给每个保留字符前加反斜杠
+func escapeMarkdownV2(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune(markdownV2Reserved, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Send 实现 Channel
+func (c *TelegramChannel) Send(ctx context.Context, subject, body string) error {
+	text := body
+	if subject != "" {
+		text = subject + "\n" + body
+	}
+
+	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", c.Token)
+	form := url.Values{
+		"chat_id":    {c.ChatID},
+		"text":       {escapeMarkdownV2(text)},
+		"parse_mode": {"MarkdownV2"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result telegramResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if !result.OK {
+		return fmt.Errorf("telegram: %s", result.Description)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WeComChannel 通过企业微信群机器人Webhook发送文本消息
+type WeComChannel struct {
+	Key string
+}
+
+// wecomResponse 是企业微信Webhook的回包，errcode非0时代表发送被静默丢弃（如限流45009）
+type wecomResponse struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// Send 实现 Channel
+func (c *WeComChannel) Send(ctx context.Context, subject, body string) error {
+	text := body
+	if subject != "" {
+		text = subject + "\n" + body
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"msgtype": "text",
+		"text":    map[string]string{"content": text},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		"https://qyapi.weixin.qq.com/cgi-bin/webhook/send?key="+c.Key, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result wecomResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if result.ErrCode != 0 {
+		return fmt.Errorf("wecom: errcode=%d errmsg=%s", result.ErrCode, result.ErrMsg)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// Package quote 定义了获取实时快照行情的统一接口 Provider，
+// 以及 JSONData 这个贯穿整个项目的行情数据结构
+package quote
+
+import (
+	"math/big"
+)
+
+// JSONData 单只基金/ETF的快照行情
+type JSONData struct {
+	Symbol        string     `json:"symbol"`        // 代码 sz169201
+	Name          string     `json:"name"`          // 名称
+	Trade         *big.Float `json:"trade"`         // 最新价
+	Pricechange   *big.Float `json:"pricechange"`   // 涨跌额
+	Changepercent *big.Float `json:"changepercent"` // 涨跌幅
+	Buy           *big.Float `json:"buy"`           // 买入
+	Sell          *big.Float `json:"sell"`          // 卖出
+	Settlement    *big.Float `json:"settlement"`    // 昨收
+	Open          *big.Float `json:"open"`          // 今开
+	High          *big.Float `json:"high"`          // 最高
+	Low           *big.Float `json:"low"`           // 最低
+	Volume        int        `json:"volume"`        // 成交量
+	Amount        int        `json:"amount"`        // 成交额
+	Code          string     `json:"code"`          // 代码 169201
+	Ticktime      string     `json:"ticktime"`      // 更新时间
+}
+
+// Provider 是行情数据源的统一抽象，codes 为纯数字代码列表
+type Provider interface {
+	FetchSnapshot(codes []string) (map[string]JSONData, error)
+}
+
+// stringToBigFloat 将字符串解析为 *big.Float
+func stringToBigFloat(s string) *big.Float {
+	f := new(big.Float)
+	f.SetString(s)
+	return f
+}
@@ -0,0 +1,86 @@
+package quote
+
+import (
+	"time"
+)
+
+// RaceProvider 同时向多个 Provider 发起请求，采用最先返回、且行情时间足够新鲜
+// （Ticktime 与当前时间之差不超过 Freshness）的结果；没有新鲜结果时退化为第一个
+// 成功返回的结果；全部失败则返回最后一个错误
+type RaceProvider struct {
+	Providers []Provider
+	Freshness time.Duration
+}
+
+// NewRaceProvider 构造 RaceProvider
+func NewRaceProvider(freshness time.Duration, providers ...Provider) *RaceProvider {
+	return &RaceProvider{Providers: providers, Freshness: freshness}
+}
+
+type raceResult struct {
+	data map[string]JSONData
+	err  error
+}
+
+// FetchSnapshot 实现 Provider
+func (p *RaceProvider) FetchSnapshot(codes []string) (map[string]JSONData, error) {
+	ch := make(chan raceResult, len(p.Providers))
+	for _, provider := range p.Providers {
+		go func(pr Provider) {
+			data, err := pr.FetchSnapshot(codes)
+			ch <- raceResult{data: data, err: err}
+		}(provider)
+	}
+
+	var fallback *raceResult
+	var lastErr error
+	for i := 0; i < len(p.Providers); i++ {
+		res := <-ch
+		if res.err != nil {
+			lastErr = res.err
+			continue
+		}
+		if p.isFresh(res.data) {
+			return res.data, nil
+		}
+		if fallback == nil {
+			fallback = &res
+		}
+	}
+
+	if fallback != nil {
+		return fallback.data, nil
+	}
+	return nil, lastErr
+}
+
+// isFresh 判断快照里最新的一条行情的 Ticktime 是否落在 Freshness 窗口内。
+//
+// 必须遍历全部行情取最新的那一条，而不能看map迭代器给出的第一条——Go的map
+// 迭代顺序每次调用都是随机的，对同一份快照数据，"第一条"可能一次是刚更新的
+// 那只基金，下一次是半天没动的那只，会让 -provider auto 在相同输入下对
+// sina/tdx 的取舍变得不确定
+func (p *RaceProvider) isFresh(data map[string]JSONData) bool {
+	if p.Freshness <= 0 {
+		return true
+	}
+
+	now := time.Now()
+	var newest time.Time
+	found := false
+	for _, q := range data {
+		t, err := time.ParseInLocation("15:04:05", q.Ticktime, time.Local)
+		if err != nil {
+			continue
+		}
+		tickTime := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location())
+		if !found || tickTime.After(newest) {
+			newest = tickTime
+			found = true
+		}
+	}
+	if !found {
+		return true
+	}
+	return now.Sub(newest) <= p.Freshness
+}
@@ -0,0 +1,124 @@
+package quote
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// UnmarshalJSON 自定义反序列化器，用于处理新浪接口返回的、以字符串形式编码的价格字段
+func (jd *JSONData) UnmarshalJSON(data []byte) error {
+	// 创建临时结构体来处理 JSON 的基本反序列化
+	type Alias JSONData
+	aux := &struct {
+		Trade         string `json:"trade"`
+		Pricechange   string `json:"pricechange"`
+		Changepercent string `json:"changepercent"`
+		Buy           string `json:"buy"`
+		Sell          string `json:"sell"`
+		Settlement    string `json:"settlement"`
+		Open          string `json:"open"`
+		High          string `json:"high"`
+		Low           string `json:"low"`
+		*Alias
+	}{
+		Alias: (*Alias)(jd),
+	}
+
+	// 先使用默认的 JSON 解析
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	// 解析字符串为 *big.Float 类型
+	jd.Trade = stringToBigFloat(aux.Trade)
+	jd.Pricechange = stringToBigFloat(aux.Pricechange)
+	jd.Changepercent = stringToBigFloat(aux.Changepercent)
+	jd.Buy = stringToBigFloat(aux.Buy)
+	jd.Sell = stringToBigFloat(aux.Sell)
+	jd.Settlement = stringToBigFloat(aux.Settlement)
+	jd.Open = stringToBigFloat(aux.Open)
+	jd.High = stringToBigFloat(aux.High)
+	jd.Low = stringToBigFloat(aux.Low)
+
+	return nil
+}
+
+// SinaProvider 通过新浪 Market_Center.getHQNodeDataSimple JSONP 接口获取快照行情
+type SinaProvider struct{}
+
+// NewSinaProvider 构造 SinaProvider
+func NewSinaProvider() *SinaProvider {
+	return &SinaProvider{}
+}
+
+// fetchFundData 从新浪网站获取某一类基金（节点）下的全部快照行情
+func fetchFundData(symbol string) ([]JSONData, error) {
+	// 基金类型映射
+	fundMap := map[string]string{
+		"封闭式基金": "close_fund",
+		"ETF基金": "etf_hq_fund",
+		"LOF基金": "lof_hq_fund",
+	}
+
+	// 构造请求URL和参数
+	url := "http://vip.stock.finance.sina.com.cn/quotes_service/api/jsonp.php/IO.XSRV2.CallbackList['da_yPT46_Ll7K6WD']/Market_Center.getHQNodeDataSimple"
+	params := "?page=1&num=1000&sort=symbol&asc=0&node=" + fundMap[symbol]
+
+	// 发起HTTP请求
+	resp, err := http.Get(url + params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// 读取响应内容
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析响应中的 JSONP 数据
+	dataText := string(body)
+
+	jsonStart := strings.Index(dataText, "([") + 1
+	jsonEnd := strings.LastIndex(dataText, "])")
+	jsonData := dataText[jsonStart : jsonEnd+1]
+
+	// 解析 JSON 数据
+	var data []JSONData
+
+	// 解析 JSON
+	if err := json.Unmarshal([]byte(jsonData), &data); err != nil {
+		return nil, fmt.Errorf("quote: 解析新浪行情数据失败: %w", err)
+	}
+
+	return data, nil
+}
+
+// convertToMap 将 JSONData 切片转换为以代码为键的 map
+func convertToMap(data []JSONData) map[string]JSONData {
+	resultMap := make(map[string]JSONData)
+	for _, item := range data {
+		resultMap[item.Code] = item
+	}
+	return resultMap
+}
+
+// FetchSnapshot 实现 Provider。新浪接口只支持按"节点"（基金品类）整表拉取，
+// 所以这里总是拉取 ETF 与 LOF 两个节点的全量数据，codes 仅用于后续调用方自行筛选
+func (p *SinaProvider) FetchSnapshot(codes []string) (map[string]JSONData, error) {
+	data1, err := fetchFundData("ETF基金")
+	if err != nil {
+		return nil, fmt.Errorf("从【ETF基金】Api获取数据时出错：%w", err)
+	}
+
+	data2, err := fetchFundData("LOF基金")
+	if err != nil {
+		return nil, fmt.Errorf("从【LOF基金】Api获取数据时出错：%w", err)
+	}
+
+	return convertToMap(append(data1, data2...)), nil
+}
@@ -0,0 +1,239 @@
+package quote
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+)
+
+// maxCodesPerFrame 是TDX SecurityQuotes协议单帧允许携带的最大代码数
+const maxCodesPerFrame = 80
+
+// methodSecurityQuotes 是 SecurityQuotes 请求对应的协议方法号
+const methodSecurityQuotes = 0x053e
+
+// marketCode 标识一只证券所属的交易所，TDX约定 0=深圳 1=上海
+type marketCode uint8
+
+const (
+	marketSZ marketCode = 0
+	marketSH marketCode = 1
+)
+
+// handshakePacket 是TCP连接建立后必须先发送一次的登录包
+var handshakePacket = []byte{0x0c, 0x02, 0x18, 0x93, 0x00, 0x01, 0x03, 0x00, 0x03, 0x00, 0x0d, 0x00, 0x01}
+
+// TDXProvider 通过TDX/L2二进制协议获取快照行情。内部维护一个到多个服务器地址的
+// TCP连接池，按帧轮询（round-robin）选择地址，单个地址出错时自动切换到下一个
+type TDXProvider struct {
+	addrs   []string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	next int
+	pool map[string]net.Conn
+}
+
+// NewTDXProvider 构造 TDXProvider，addrs 为 "host:port" 形式的服务器地址列表
+func NewTDXProvider(addrs []string, timeout time.Duration) *TDXProvider {
+	return &TDXProvider{addrs: addrs, timeout: timeout, pool: make(map[string]net.Conn)}
+}
+
+// pickAddr 轮询返回下一个服务器地址
+func (p *TDXProvider) pickAddr() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.addrs) == 0 {
+		return "", fmt.Errorf("quote: 未配置TDX服务器地址")
+	}
+	addr := p.addrs[p.next%len(p.addrs)]
+	p.next++
+	return addr, nil
+}
+
+// conn 返回到 addr 的连接，池中没有则新建并完成握手
+func (p *TDXProvider) conn(addr string) (net.Conn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.pool[addr]; ok {
+		return c, nil
+	}
+
+	c, err := net.DialTimeout("tcp", addr, p.timeout)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.Write(handshakePacket); err != nil {
+		c.Close()
+		return nil, err
+	}
+	ack := make([]byte, 256)
+	if _, err := c.Read(ack); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	p.pool[addr] = c
+	return c, nil
+}
+
+// dropConn 关闭并清除某个地址的连接，下次会重新握手建立
+func (p *TDXProvider) dropConn(addr string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if c, ok := p.pool[addr]; ok {
+		c.Close()
+		delete(p.pool, addr)
+	}
+}
+
+// splitCode 把纯数字代码拆成TDX约定的 (market, code[6]byte) 元组
+func splitCode(code string) (marketCode, [6]byte) {
+	market := marketSZ
+	if len(code) > 0 && (code[0] == '5' || code[0] == '6' || code[0] == '9') {
+		market = marketSH
+	}
+	var buf [6]byte
+	copy(buf[:], code)
+	return market, buf
+}
+
+// buildSecurityQuotesPayload 按 (market, code) 列表构造 SecurityQuotes 请求的业务payload
+func buildSecurityQuotesPayload(codes []string) []byte {
+	buf := make([]byte, 0, 2+7*len(codes))
+	buf = append(buf, byte(len(codes)), 0x00)
+	for _, code := range codes {
+		market, raw := splitCode(code)
+		buf = append(buf, byte(market))
+		buf = append(buf, raw[:]...)
+	}
+	return buf
+}
+
+// FetchSnapshot 实现 Provider：将codes按 maxCodesPerFrame 分帧，每帧轮询一个连接请求，
+// 合并所有帧的结果后返回
+func (p *TDXProvider) FetchSnapshot(codes []string) (map[string]JSONData, error) {
+	result := make(map[string]JSONData, len(codes))
+
+	for start := 0; start < len(codes); start += maxCodesPerFrame {
+		end := start + maxCodesPerFrame
+		if end > len(codes) {
+			end = len(codes)
+		}
+		batch := codes[start:end]
+
+		// 依次尝试各个地址：建连或请求失败时丢弃该地址的连接并换下一个，全部失败才返回最后一个错误
+		var quotes map[string]JSONData
+		var err error
+		for attempt := 0; attempt == 0 || attempt < len(p.addrs); attempt++ {
+			var addr string
+			if addr, err = p.pickAddr(); err != nil {
+				return nil, err
+			}
+			var conn net.Conn
+			if conn, err = p.conn(addr); err != nil {
+				continue
+			}
+			if quotes, err = p.requestBatch(conn, batch); err == nil {
+				break
+			}
+			p.dropConn(addr)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for code, q := range quotes {
+			result[code] = q
+		}
+	}
+
+	return result, nil
+}
+
+// requestBatch 发送一帧 SecurityQuotes 请求并解析回包
+func (p *TDXProvider) requestBatch(conn net.Conn, codes []string) (map[string]JSONData, error) {
+	payload := buildSecurityQuotesPayload(codes)
+
+	header := make([]byte, 12)
+	binary.LittleEndian.PutUint16(header[0:2], uint16(len(payload)+2))
+	binary.LittleEndian.PutUint16(header[2:4], uint16(len(payload)+2))
+	binary.LittleEndian.PutUint16(header[4:6], methodSecurityQuotes)
+
+	if p.timeout > 0 {
+		conn.SetDeadline(time.Now().Add(p.timeout))
+	}
+	if _, err := conn.Write(append(header, payload...)); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	lenBuf := make([]byte, 2)
+	if _, err := io.ReadFull(reader, lenBuf); err != nil {
+		return nil, err
+	}
+	bodyLen := binary.LittleEndian.Uint16(lenBuf)
+
+	body := make([]byte, bodyLen)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return nil, err
+	}
+
+	return decodeSecurityQuotes(body, codes)
+}
+
+// quoteRecordSize 是单只证券在 SecurityQuotes 回包中的定长字节数：12个8字节小端uint64，
+// 依次为 现价/昨收/今开/最高/最低、买一/卖一价、成交量、成交额、买一/卖一量、服务器时间；
+// 价格类字段是放大1000倍后的整数
+const quoteRecordSize = 8*5 + 8*2 + 8 + 8 + 8*2 + 8
+
+// decodeSecurityQuotes 解析 SecurityQuotes 回包，按发出请求时的codes顺序逐条解码
+func decodeSecurityQuotes(body []byte, codes []string) (map[string]JSONData, error) {
+	result := make(map[string]JSONData, len(codes))
+
+	for i, code := range codes {
+		offset := i * quoteRecordSize
+		if offset+quoteRecordSize > len(body) {
+			break
+		}
+		rec := body[offset : offset+quoteRecordSize]
+
+		readFloat := func(n int) *big.Float {
+			raw := binary.LittleEndian.Uint64(rec[n*8 : n*8+8])
+			return new(big.Float).Quo(new(big.Float).SetUint64(raw), big.NewFloat(1000))
+		}
+
+		price := readFloat(0)
+		lastClose := readFloat(1)
+		open := readFloat(2)
+		high := readFloat(3)
+		low := readFloat(4)
+		bid1 := readFloat(5)
+		ask1 := readFloat(6)
+		volume := binary.LittleEndian.Uint64(rec[7*8 : 7*8+8])
+		amount := binary.LittleEndian.Uint64(rec[8*8 : 8*8+8])
+		// 下标9、10的 bidVol1/askVol1 不解码（JSONData 没有对应字段），serverTime 落到 JSONData.Ticktime
+		serverTime := binary.LittleEndian.Uint64(rec[11*8 : 11*8+8])
+
+		result[code] = JSONData{
+			Code:       code,
+			Trade:      price,
+			Settlement: lastClose,
+			Open:       open,
+			High:       high,
+			Low:        low,
+			Buy:        bid1,
+			Sell:       ask1,
+			Volume:     int(volume),
+			Amount:     int(amount),
+			Ticktime:   time.Unix(int64(serverTime), 0).Format("15:04:05"),
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package quote
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+// encodeQuoteRecord 按 decodeSecurityQuotes 的编码约定手工拼出一条定长记录，
+// 价格类字段放大1000倍存成uint64，量/额和服务器时间按uint64直接写入
+func encodeQuoteRecord(price, lastClose, open, high, low, bid1, ask1 float64, volume, amount, serverTime uint64) []byte {
+	rec := make([]byte, quoteRecordSize)
+
+	putPrice := func(n int, v float64) {
+		binary.LittleEndian.PutUint64(rec[n*8:n*8+8], uint64(v*1000))
+	}
+	putPrice(0, price)
+	putPrice(1, lastClose)
+	putPrice(2, open)
+	putPrice(3, high)
+	putPrice(4, low)
+	putPrice(5, bid1)
+	putPrice(6, ask1)
+	binary.LittleEndian.PutUint64(rec[7*8:7*8+8], volume)
+	binary.LittleEndian.PutUint64(rec[8*8:8*8+8], amount)
+	binary.LittleEndian.PutUint64(rec[11*8:11*8+8], serverTime)
+
+	return rec
+}
+
+// TestDecodeSecurityQuotesRoundTrip 验证按 quoteRecordSize 定长拼出的多条记录能够
+// 按codes原始顺序逐条解码回正确的字段
+func TestDecodeSecurityQuotesRoundTrip(t *testing.T) {
+	serverTime := uint64(time.Date(2026, 7, 26, 9, 31, 5, 0, time.Local).Unix())
+
+	var body []byte
+	body = append(body, encodeQuoteRecord(10.01, 9.98, 10.00, 10.20, 9.90, 10.00, 10.01, 123456, 987654, serverTime)...)
+	body = append(body, encodeQuoteRecord(20.50, 20.00, 20.10, 20.60, 19.80, 20.49, 20.50, 222, 333, serverTime)...)
+
+	codes := []string{"000001", "600000"}
+	result, err := decodeSecurityQuotes(body, codes)
+	if err != nil {
+		t.Fatalf("decodeSecurityQuotes 返回错误: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("len(result) = %d, want 2", len(result))
+	}
+
+	wantTick := time.Unix(int64(serverTime), 0).Format("15:04:05")
+
+	first := result["000001"]
+	if got, _ := first.Trade.Float64(); got != 10.01 {
+		t.Errorf("000001 Trade = %v, want 10.01", got)
+	}
+	if got, _ := first.Open.Float64(); got != 10.00 {
+		t.Errorf("000001 Open = %v, want 10.00", got)
+	}
+	if first.Volume != 123456 {
+		t.Errorf("000001 Volume = %d, want 123456", first.Volume)
+	}
+	if first.Ticktime != wantTick {
+		t.Errorf("000001 Ticktime = %q, want %q", first.Ticktime, wantTick)
+	}
+
+	second := result["600000"]
+	if got, _ := second.Trade.Float64(); got != 20.50 {
+		t.Errorf("600000 Trade = %v, want 20.50", got)
+	}
+	if second.Volume != 222 {
+		t.Errorf("600000 Volume = %d, want 222", second.Volume)
+	}
+}
+
+// TestDecodeSecurityQuotesTruncatedBody 验证回包比请求的代码数少（最后一条记录被截断）
+// 时只解码出能凑够 quoteRecordSize 的那些记录，而不是越界panic
+func TestDecodeSecurityQuotesTruncatedBody(t *testing.T) {
+	body := encodeQuoteRecord(1, 1, 1, 1, 1, 1, 1, 1, 1, 1)
+	body = append(body, make([]byte, quoteRecordSize/2)...)
+
+	codes := []string{"000001", "600000"}
+	result, err := decodeSecurityQuotes(body, codes)
+	if err != nil {
+		t.Fatalf("decodeSecurityQuotes 返回错误: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("len(result) = %d, want 1", len(result))
+	}
+	if _, ok := result["000001"]; !ok {
+		t.Errorf("缺少 000001 的解码结果")
+	}
+	if _, ok := result["600000"]; ok {
+		t.Errorf("600000 对应的记录被截断，不应该出现在结果里")
+	}
+}
@@ -0,0 +1,206 @@
+// Package session 负责判断A股交易时段（集合竞价/连续竞价/休市/非交易日），
+// 使调用方可以在每次轮询前判断当前时刻是否值得请求行情接口。
+package session
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeKind 表示某个时间点所处的交易时段类型
+type TimeKind int
+
+const (
+	// KindNonTradingDay 非交易日（周末、节假日）
+	KindNonTradingDay TimeKind = iota
+	// KindClosed 交易日内但不在任何配置时段中（已收盘或尚未开始）
+	KindClosed
+	// KindCAAC 集合竞价-可撤单阶段（如 09:15-09:20）
+	KindCAAC
+	// KindCA 集合竞价-不可撤单阶段（如 09:20-09:25）
+	KindCA
+	// KindContinuous 连续竞价阶段（如 09:30-11:30、13:00-15:00）
+	KindContinuous
+)
+
+// String 返回时段类型的简短标识，便于日志打印
+func (k TimeKind) String() string {
+	switch k {
+	case KindNonTradingDay:
+		return "NONTRADING"
+	case KindClosed:
+		return "CLOSED"
+	case KindCAAC:
+		return "CAAC"
+	case KindCA:
+		return "CA"
+	case KindContinuous:
+		return "CONT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// kindFromToken 将配置字符串中的时段标记转换为 TimeKind
+func kindFromToken(token string) (TimeKind, error) {
+	switch token {
+	case "CAAC":
+		return KindCAAC, nil
+	case "CA":
+		return KindCA, nil
+	case "CONT":
+		return KindContinuous, nil
+	default:
+		return KindClosed, fmt.Errorf("session: 未知的时段标记 %q", token)
+	}
+}
+
+// Session 表示一个配置好的交易时段，Start/End 为从当天 00:00:00 起算的偏移
+type Session struct {
+	Kind  TimeKind
+	Start time.Duration
+	End   time.Duration
+}
+
+// MarketHours 一天内所有配置的交易时段，按配置串中出现的先后顺序排列（不按 Start 重新排序）
+type MarketHours struct {
+	Sessions []Session
+}
+
+// ParseMarketHours 解析形如
+// "CAAC|09:15:00-09:20:00,CA|09:20:00-09:25:00,CONT|09:30:00-11:30:00,CONT|13:00:00-15:00:00"
+// 的配置字符串
+func ParseMarketHours(config string) (*MarketHours, error) {
+	mh := &MarketHours{}
+	for _, item := range strings.Split(config, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "|", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("session: 非法的时段配置 %q", item)
+		}
+		kind, err := kindFromToken(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, err
+		}
+		rang := strings.SplitN(parts[1], "-", 2)
+		if len(rang) != 2 {
+			return nil, fmt.Errorf("session: 非法的时段区间 %q", parts[1])
+		}
+		start, err := parseClock(rang[0])
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseClock(rang[1])
+		if err != nil {
+			return nil, err
+		}
+		mh.Sessions = append(mh.Sessions, Session{Kind: kind, Start: start, End: end})
+	}
+	return mh, nil
+}
+
+// parseClock 将 "HH:MM:SS" 解析为从当天 00:00:00 起算的 time.Duration
+func parseClock(clock string) (time.Duration, error) {
+	parts := strings.Split(clock, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("session: 非法的时刻 %q", clock)
+	}
+	h, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, err
+	}
+	m, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, err
+	}
+	s, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second, nil
+}
+
+// HolidayCalendar 判断某天是否为非交易日，便于注入自定义的节假日数据源
+type HolidayCalendar interface {
+	IsNonTradingDay(t time.Time) bool
+}
+
+// StaticHolidayCalendar 基于固定的 "YYYY-MM-DD" 节假日列表实现 HolidayCalendar，
+// 同时将周六、周日视为非交易日
+type StaticHolidayCalendar struct {
+	dates map[string]struct{}
+}
+
+// NewStaticHolidayCalendar 从 "YYYY-MM-DD" 格式的日期列表构造 StaticHolidayCalendar
+func NewStaticHolidayCalendar(dates []string) (*StaticHolidayCalendar, error) {
+	set := make(map[string]struct{}, len(dates))
+	for _, d := range dates {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return nil, fmt.Errorf("session: 非法的节假日 %q: %w", d, err)
+		}
+		set[d] = struct{}{}
+	}
+	return &StaticHolidayCalendar{dates: set}, nil
+}
+
+// IsNonTradingDay 实现 HolidayCalendar
+func (c *StaticHolidayCalendar) IsNonTradingDay(t time.Time) bool {
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return true
+	}
+	_, ok := c.dates[t.Format("2006-01-02")]
+	return ok
+}
+
+// Operator 组合 MarketHours 与 HolidayCalendar，对外提供时刻分类能力
+type Operator struct {
+	Hours    *MarketHours
+	Holidays HolidayCalendar
+}
+
+// NewOperator 构造 Operator，holidays 为 nil 时视为没有节假日（仅跳过周末）
+func NewOperator(hours *MarketHours, holidays HolidayCalendar) *Operator {
+	return &Operator{Hours: hours, Holidays: holidays}
+}
+
+// Kind 将毫秒时间戳 ms 分类为某个 TimeKind，并返回其在 Hours.Sessions 中的下标
+// （闭市/非交易日时 index 为 -1）
+func (o *Operator) Kind(ms int64) (TimeKind, int) {
+	t := time.UnixMilli(ms)
+
+	var nonTrading bool
+	if o.Holidays != nil {
+		nonTrading = o.Holidays.IsNonTradingDay(t)
+	} else {
+		wd := t.Weekday()
+		nonTrading = wd == time.Saturday || wd == time.Sunday
+	}
+	if nonTrading {
+		return KindNonTradingDay, -1
+	}
+
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	elapsed := t.Sub(midnight)
+
+	for i, s := range o.Hours.Sessions {
+		if elapsed >= s.Start && elapsed < s.End {
+			return s.Kind, i
+		}
+	}
+	return KindClosed, -1
+}
+
+// SessionDate 返回 ms 所属交易日的日期串（"YYYY-MM-DD"），用于按交易时段（而非自然日
+// 午夜）为维度重置状态
+func (o *Operator) SessionDate(ms int64) string {
+	return time.UnixMilli(ms).Format("2006-01-02")
+}
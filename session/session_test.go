@@ -0,0 +1,107 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func mustOperator(t *testing.T, holidays []string) *Operator {
+	t.Helper()
+
+	hours, err := ParseMarketHours(
+		"CAAC|09:15:00-09:20:00,CA|09:20:00-09:25:00,CONT|09:30:00-11:30:00,CONT|13:00:00-15:00:00",
+	)
+	if err != nil {
+		t.Fatalf("ParseMarketHours失败: %v", err)
+	}
+
+	calendar, err := NewStaticHolidayCalendar(holidays)
+	if err != nil {
+		t.Fatalf("NewStaticHolidayCalendar失败: %v", err)
+	}
+
+	return NewOperator(hours, calendar)
+}
+
+// at 构造 2024-01-02（周二，非节假日）当天 hh:mm:ss 对应的毫秒时间戳
+func at(hh, mm, ss int) int64 {
+	return time.Date(2024, 1, 2, hh, mm, ss, 0, time.Local).UnixMilli()
+}
+
+// TestOperatorKindBoundaries 覆盖集合竞价/连续竞价各时段的边界：每个Session按
+// [Start, End) 左闭右开，因此边界秒既要验证"刚进入"也要验证"刚离开"
+func TestOperatorKindBoundaries(t *testing.T) {
+	op := mustOperator(t, nil)
+
+	cases := []struct {
+		name string
+		ms   int64
+		want TimeKind
+	}{
+		{"盘前", at(9, 0, 0), KindClosed},
+		{"CAAC开始", at(9, 15, 0), KindCAAC},
+		{"CAAC中间", at(9, 17, 30), KindCAAC},
+		{"CAAC结束前一秒", at(9, 19, 59), KindCAAC},
+		{"CA开始（即CAAC结束）", at(9, 20, 0), KindCA},
+		{"CA结束前一秒", at(9, 24, 59), KindCA},
+		{"CA结束到CONT开始之间", at(9, 25, 0), KindClosed},
+		{"CONT开始前一秒", at(9, 29, 59), KindClosed},
+		{"CONT开始", at(9, 30, 0), KindContinuous},
+		{"CONT中间", at(10, 0, 0), KindContinuous},
+		{"CONT结束前一秒", at(11, 29, 59), KindContinuous},
+		{"午间休市", at(11, 30, 0), KindClosed},
+		{"午间休市中段", at(12, 0, 0), KindClosed},
+		{"下午CONT开始", at(13, 0, 0), KindContinuous},
+		{"下午CONT结束前一秒", at(14, 59, 59), KindContinuous},
+		{"收盘", at(15, 0, 0), KindClosed},
+		{"深夜", at(22, 0, 0), KindClosed},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got, _ := op.Kind(c.ms); got != c.want {
+				t.Errorf("Kind() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestOperatorKindNonTradingDay 验证周末与节假日列表都会被判定为非交易日，
+// 优先级高于当天具体时刻落在哪个时段
+func TestOperatorKindNonTradingDay(t *testing.T) {
+	op := mustOperator(t, []string{"2024-01-03"})
+
+	saturday := time.Date(2024, 1, 6, 10, 0, 0, 0, time.Local).UnixMilli()
+	if got, _ := op.Kind(saturday); got != KindNonTradingDay {
+		t.Errorf("周六 Kind() = %v, want KindNonTradingDay", got)
+	}
+
+	holiday := time.Date(2024, 1, 3, 10, 0, 0, 0, time.Local).UnixMilli()
+	if got, _ := op.Kind(holiday); got != KindNonTradingDay {
+		t.Errorf("节假日 Kind() = %v, want KindNonTradingDay", got)
+	}
+
+	tradingDay := time.Date(2024, 1, 2, 10, 0, 0, 0, time.Local).UnixMilli()
+	if got, _ := op.Kind(tradingDay); got != KindContinuous {
+		t.Errorf("普通交易日 Kind() = %v, want KindContinuous", got)
+	}
+}
+
+// TestOperatorKindIndex 验证返回的 index 指向 Hours.Sessions 里对应的配置项，
+// 闭市/非交易日时为 -1
+func TestOperatorKindIndex(t *testing.T) {
+	op := mustOperator(t, nil)
+
+	if _, idx := op.Kind(at(9, 17, 0)); idx != 0 {
+		t.Errorf("CAAC阶段 index = %d, want 0", idx)
+	}
+	if _, idx := op.Kind(at(9, 22, 0)); idx != 1 {
+		t.Errorf("CA阶段 index = %d, want 1", idx)
+	}
+	if _, idx := op.Kind(at(13, 0, 0)); idx != 3 {
+		t.Errorf("下午CONT阶段 index = %d, want 3", idx)
+	}
+	if _, idx := op.Kind(at(12, 0, 0)); idx != -1 {
+		t.Errorf("午间休市 index = %d, want -1", idx)
+	}
+}
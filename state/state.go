@@ -0,0 +1,84 @@
+// Package state 提供一个将任意结构体快照到磁盘并在下次启动时恢复的通用存储，
+// 用于在进程重启（容器重新部署、崩溃）后不丢失当天的告警状态
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// formatVersion 是落盘文件的结构版本号。未来增删字段时，旧版本文件仍可被
+// json.Unmarshal 正常解析（未知字段被忽略，缺失字段取零值），无需在这里升级；
+// 只有当文件的整体语义发生不兼容变化时才需要提升它
+const formatVersion = 1
+
+// fileFormat 是实际落盘的JSON结构
+type fileFormat[T any] struct {
+	Version int `json:"version"`
+	Data    T   `json:"data"`
+}
+
+// Store 负责把类型 T 的快照以防抖（debounce）的方式写入磁盘文件
+type Store[T any] struct {
+	path     string
+	debounce time.Duration
+
+	mu      sync.Mutex
+	pending T
+	dirty   bool
+	timer   *time.Timer
+}
+
+// NewStore 构造一个写入 path 的 Store，debounce 为两次落盘之间的最小间隔
+func NewStore[T any](path string, debounce time.Duration) *Store[T] {
+	return &Store[T]{path: path, debounce: debounce}
+}
+
+// Load 从磁盘读取上一次保存的快照；文件不存在或解析失败时返回 error
+func (s *Store[T]) Load() (T, error) {
+	var zero T
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return zero, err
+	}
+
+	var ff fileFormat[T]
+	if err := json.Unmarshal(data, &ff); err != nil {
+		return zero, err
+	}
+	return ff.Data, nil
+}
+
+// Save 记录最新快照并安排一次落盘；debounce 窗口内的多次调用只会触发一次实际写盘
+func (s *Store[T]) Save(v T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = v
+	s.dirty = true
+	if s.timer == nil {
+		s.timer = time.AfterFunc(s.debounce, s.flush)
+	}
+}
+
+// flush 把当前 pending 的快照写入磁盘
+func (s *Store[T]) flush() {
+	s.mu.Lock()
+	if !s.dirty {
+		s.mu.Unlock()
+		return
+	}
+	v := s.pending
+	s.dirty = false
+	s.timer = nil
+	s.mu.Unlock()
+
+	data, err := json.Marshal(fileFormat[T]{Version: formatVersion, Data: v})
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(s.path, data, 0644)
+}
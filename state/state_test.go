@@ -0,0 +1,60 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestStoreSaveDebounce 验证debounce窗口内的多次Save只会触发一次落盘，
+// 且落盘的是窗口关闭前最后一次Save的值
+func TestStoreSaveDebounce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s := NewStore[map[string]int](path, 50*time.Millisecond)
+
+	s.Save(map[string]int{"a": 1})
+	s.Save(map[string]int{"a": 2})
+	s.Save(map[string]int{"a": 3})
+
+	if _, err := os.Stat(path); err == nil {
+		t.Fatalf("debounce窗口内不应该已经落盘")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	loaded, err := NewStore[map[string]int](path, 0).Load()
+	if err != nil {
+		t.Fatalf("debounce窗口后Load失败: %v", err)
+	}
+	if loaded["a"] != 3 {
+		t.Errorf("落盘内容 = %v, want 最后一次Save的值 map[a:3]", loaded)
+	}
+}
+
+// TestStoreLoadRoundTrip 验证Save落盘后，新建的Store能用Load读回同样的数据
+func TestStoreLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s := NewStore[map[string]string](path, 10*time.Millisecond)
+
+	s.Save(map[string]string{"code1": "x", "code2": "y"})
+	time.Sleep(50 * time.Millisecond)
+
+	loaded, err := NewStore[map[string]string](path, 0).Load()
+	if err != nil {
+		t.Fatalf("Load失败: %v", err)
+	}
+	if loaded["code1"] != "x" || loaded["code2"] != "y" {
+		t.Errorf("loaded = %v, want map[code1:x code2:y]", loaded)
+	}
+}
+
+// TestStoreLoadMissingFile 验证文件不存在时Load返回error而不是零值掩盖问题，
+// 这样main.go里 stateStore.Load() 失败时可以按"没有历史状态"正确处理
+func TestStoreLoadMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist.json")
+
+	if _, err := NewStore[map[string]int](path, time.Second).Load(); err == nil {
+		t.Fatalf("文件不存在时Load应该返回错误")
+	}
+}